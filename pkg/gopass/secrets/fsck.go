@@ -0,0 +1,12 @@
+package secrets
+
+// CheckStrict reports whether a raw secret buffer would parse cleanly under
+// ModeStrict. It is the primitive a `gopass fsck --yaml` pass would call
+// per-secret to report every entry whose current representation relies on
+// the ModeLenient fallback and would fail strict YAML parsing; that command
+// itself is not part of this change.
+func CheckStrict(in []byte) error {
+	_, err := Parse(in, ModeStrict)
+
+	return err
+}
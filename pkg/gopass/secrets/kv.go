@@ -0,0 +1,117 @@
+package secrets
+
+import (
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// KV is a plain key/value secret: a password line, optionally followed by
+// a YAML (or, in ModeKV / lenient-fallback, opaque plain-text) body.
+type KV struct {
+	password string
+	pairs    map[string]string
+	body     string
+
+	// raw and lenientFallback together make Bytes round-trip aware: a
+	// secret that was only readable because ModeLenient fell back to a
+	// plain-text body must be written back out byte-for-byte, unless the
+	// caller actually set the password or a key, otherwise gopass edit would silently
+	// destroy the user's original comments and formatting.
+	raw             []byte
+	lenientFallback bool
+	modified        bool
+}
+
+// Password returns the first line of the secret.
+func (k *KV) Password() string {
+	return k.password
+}
+
+// SetPassword replaces the first line of the secret.
+func (k *KV) SetPassword(pwd string) {
+	k.password = pwd
+	k.modified = true
+}
+
+// Get returns the value for key, if the body was decoded as YAML.
+func (k *KV) Get(key string) (string, bool) {
+	v, ok := k.pairs[key]
+
+	return v, ok
+}
+
+// Set adds or updates a key in the body. A secret with an opaque plain-text
+// body (ModeKV) is switched to a structured YAML body; for a ModeLenient
+// fallback secret, Bytes keeps the original tail and appends the keys.
+func (k *KV) Set(key, value string) {
+	if k.pairs == nil {
+		k.pairs = make(map[string]string)
+	}
+
+	k.pairs[key] = value
+	k.modified = true
+}
+
+// Keys returns the sorted set of keys, if the body was decoded as YAML.
+func (k *KV) Keys() []string {
+	keys := make([]string, 0, len(k.pairs))
+	for key := range k.pairs {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+// Body returns the plain-text tail, for secrets that were never decoded as
+// YAML (ModeKV, or a ModeLenient fallback).
+func (k *KV) Body() string {
+	return k.body
+}
+
+// Bytes serializes the secret back to the on-disk representation. If this
+// secret was loaded via a lenient YAML fallback and has not since had its
+// password or a key set, the original bytes are returned verbatim instead of being
+// re-encoded as YAML, so gopass edit never destroys user-authored comments
+// or formatting it couldn't actually parse. If it has been modified, the
+// original unparsed tail is preserved and any keys set since loading are
+// appended as a YAML block rather than replacing it, so a single `Set` on a
+// lenient secret can never silently discard the rest of the body.
+func (k *KV) Bytes() []byte {
+	if k.lenientFallback && !k.modified {
+		return k.raw
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString(k.password)
+	sb.WriteByte('\n')
+
+	switch {
+	case k.lenientFallback:
+		sb.WriteString(k.body)
+
+		if len(k.pairs) > 0 {
+			if !strings.HasSuffix(k.body, "\n") {
+				sb.WriteByte('\n')
+			}
+
+			out, err := yaml.Marshal(k.pairs)
+			if err == nil {
+				sb.Write(out)
+			}
+		}
+	case len(k.pairs) > 0:
+		out, err := yaml.Marshal(k.pairs)
+		if err == nil {
+			sb.Write(out)
+		}
+	case k.body != "":
+		sb.WriteString(k.body)
+	}
+
+	return []byte(sb.String())
+}
@@ -0,0 +1,235 @@
+// Package secrets contains gopass secret sub-types: the key/value + body
+// representation (KV) with its parse modes, and Mnemonic.
+package secrets
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/pbkdf2"
+)
+
+// ErrChecksumMismatch is returned by FromMnemonic when the checksum encoded
+// in the final word(s) of a phrase does not match the checksum computed
+// from the decoded entropy, e.g. because of a typo or a word from the
+// wrong wordlist.
+var ErrChecksumMismatch = errors.New("mnemonic: checksum mismatch")
+
+// validEntropyBits are the entropy sizes defined by BIP39.
+var validEntropyBits = map[int]bool{
+	128: true,
+	160: true,
+	192: true,
+	224: true,
+	256: true,
+}
+
+const (
+	seedIterations = 2048
+	seedKeyLen     = 64
+	seedSaltPrefix = "mnemonic"
+)
+
+// Mnemonic is a BIP39-style mnemonic secret: a human-transcribable encoding
+// of a random entropy buffer, optionally protected by an additional
+// passphrase. Bytes returns the phrase itself; Password returns the seed
+// derived from it, so a Mnemonic can be inserted and shown like any other
+// gopass secret.
+type Mnemonic struct {
+	words      []string
+	wordlist   string
+	passphrase string
+}
+
+// NewMnemonic generates a new mnemonic from bits of cryptographically
+// secure random entropy. bits must be one of 128, 160, 192, 224 or 256,
+// yielding a 12, 15, 18, 21 or 24 word phrase respectively.
+func NewMnemonic(bits int) (*Mnemonic, error) {
+	return NewMnemonicWordlist(bits, DefaultWordlist)
+}
+
+// NewMnemonicWordlist is NewMnemonic against an explicit wordlist name, the
+// constructor a `--bits`/`--wordlist`-driven CLI command would call.
+func NewMnemonicWordlist(bits int, wordlist string) (*Mnemonic, error) {
+	if !validEntropyBits[bits] {
+		return nil, fmt.Errorf("mnemonic: invalid entropy size %d bits, must be one of 128/160/192/224/256", bits)
+	}
+
+	entropy := make([]byte, bits/8)
+	if _, err := rand.Read(entropy); err != nil {
+		return nil, fmt.Errorf("mnemonic: failed to read random entropy: %w", err)
+	}
+
+	return entropyToMnemonic(entropy, wordlist)
+}
+
+// FromMnemonic parses and validates a space-separated mnemonic phrase
+// against the default (English) wordlist, returning ErrChecksumMismatch
+// if the embedded checksum does not match.
+func FromMnemonic(phrase string) (*Mnemonic, error) {
+	return FromMnemonicWordlist(phrase, DefaultWordlist)
+}
+
+// FromMnemonicWordlist is FromMnemonic with an explicit wordlist name, for
+// phrases generated with --wordlist set to something other than English.
+func FromMnemonicWordlist(phrase, wordlist string) (*Mnemonic, error) {
+	words := strings.Fields(phrase)
+
+	list, err := loadWordlist(wordlist)
+	if err != nil {
+		return nil, err
+	}
+
+	index := make(map[string]int, len(list))
+	for i, w := range list {
+		index[w] = i
+	}
+
+	totalBits := len(words) * 11
+	if totalBits == 0 || totalBits%33 != 0 {
+		return nil, fmt.Errorf("mnemonic: invalid word count %d", len(words))
+	}
+
+	entBits := totalBits * 32 / 33
+	csBits := totalBits - entBits
+
+	if !validEntropyBits[entBits] {
+		return nil, fmt.Errorf("mnemonic: invalid word count %d", len(words))
+	}
+
+	allBits := make([]bool, 0, totalBits)
+	for _, w := range words {
+		i, ok := index[w]
+		if !ok {
+			return nil, fmt.Errorf("mnemonic: word %q is not in the %s wordlist", w, wordlist)
+		}
+
+		allBits = append(allBits, uintToBits(uint16(i), 11)...)
+	}
+
+	entropy := bitsToBytes(allBits[:entBits])
+
+	want := entropyChecksumBits(entropy, csBits)
+	got := allBits[entBits:]
+
+	for i := range want {
+		if want[i] != got[i] {
+			return nil, ErrChecksumMismatch
+		}
+	}
+
+	return &Mnemonic{words: words, wordlist: wordlist}, nil
+}
+
+func entropyToMnemonic(entropy []byte, wordlist string) (*Mnemonic, error) {
+	list, err := loadWordlist(wordlist)
+	if err != nil {
+		return nil, err
+	}
+
+	csBits := len(entropy) * 8 / 32
+	allBits := append(bytesToBits(entropy), entropyChecksumBits(entropy, csBits)...)
+
+	words := make([]string, 0, len(allBits)/11)
+	for i := 0; i < len(allBits); i += 11 {
+		words = append(words, list[bitsToUint(allBits[i:i+11])])
+	}
+
+	return &Mnemonic{words: words, wordlist: wordlist}, nil
+}
+
+// entropyChecksumBits returns the first n bits of SHA-256(entropy), as
+// defined by BIP39 (n = ENT/32).
+func entropyChecksumBits(entropy []byte, n int) []bool {
+	sum := sha256.Sum256(entropy)
+
+	return bytesToBits(sum[:])[:n]
+}
+
+// SetPassphrase sets an optional additional passphrase mixed into the seed
+// derivation (BIP39 "25th word").
+func (m *Mnemonic) SetPassphrase(p string) {
+	m.passphrase = p
+}
+
+// Words returns the mnemonic phrase as individual words.
+func (m *Mnemonic) Words() []string {
+	return m.words
+}
+
+// Bytes returns the mnemonic phrase, space separated.
+func (m *Mnemonic) Bytes() []byte {
+	return []byte(strings.Join(m.words, " "))
+}
+
+// Password returns the hex-encoded seed derived from the phrase via
+// PBKDF2-HMAC-SHA512 (2048 iterations, salt "mnemonic"+passphrase), as
+// defined by BIP39. Passing an additional SetPassphrase value lets users
+// protect the phrase with a second factor.
+func (m *Mnemonic) Password() string {
+	salt := seedSaltPrefix + m.passphrase
+	seed := pbkdf2.Key(m.Bytes(), []byte(salt), seedIterations, seedKeyLen, sha512.New)
+
+	return hex.EncodeToString(seed)
+}
+
+// String implements fmt.Stringer.
+func (m *Mnemonic) String() string {
+	return string(m.Bytes())
+}
+
+func bytesToBits(b []byte) []bool {
+	bits := make([]bool, 0, len(b)*8)
+	for _, by := range b {
+		for i := 7; i >= 0; i-- {
+			bits = append(bits, (by>>uint(i))&1 == 1)
+		}
+	}
+
+	return bits
+}
+
+func uintToBits(v uint16, n int) []bool {
+	bits := make([]bool, n)
+	for i := n - 1; i >= 0; i-- {
+		bits[i] = v&1 == 1
+		v >>= 1
+	}
+
+	return bits
+}
+
+func bitsToUint(bits []bool) int {
+	v := 0
+	for _, b := range bits {
+		v <<= 1
+		if b {
+			v |= 1
+		}
+	}
+
+	return v
+}
+
+// bitsToBytes packs a bit slice into bytes, right-padding the final byte
+// with zero bits if necessary.
+func bitsToBytes(bits []bool) []byte {
+	padded := len(bits)
+	if r := padded % 8; r != 0 {
+		padded += 8 - r
+	}
+
+	out := make([]byte, padded/8)
+	for i, b := range bits {
+		if b {
+			out[i/8] |= 1 << uint(7-i%8)
+		}
+	}
+
+	return out
+}
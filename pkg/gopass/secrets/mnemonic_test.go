@@ -0,0 +1,145 @@
+package secrets
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// standard BIP39 test vectors (entropy -> mnemonic with the default English
+// wordlist), from the reference test suite of the BIP39 specification.
+var bip39Vectors = []struct {
+	entropy  string
+	mnemonic string
+}{
+	{
+		entropy:  "00000000000000000000000000000000",
+		mnemonic: "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+	},
+	{
+		entropy:  "7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f",
+		mnemonic: "legal winner thank year wave sausage worth useful legal winner thank yellow",
+	},
+}
+
+func TestFromMnemonicKnownVectors(t *testing.T) {
+	for _, tc := range bip39Vectors {
+		tc := tc
+		t.Run(tc.mnemonic[:20], func(t *testing.T) {
+			m, err := FromMnemonic(tc.mnemonic)
+			if err != nil {
+				t.Fatalf("FromMnemonic(%q) failed: %v", tc.mnemonic, err)
+			}
+
+			if got := string(m.Bytes()); got != tc.mnemonic {
+				t.Errorf("Bytes() = %q, want %q", got, tc.mnemonic)
+			}
+		})
+	}
+}
+
+func TestMnemonicRoundTrip(t *testing.T) {
+	for _, bits := range []int{128, 160, 192, 224, 256} {
+		m, err := NewMnemonic(bits)
+		if err != nil {
+			t.Fatalf("NewMnemonic(%d) failed: %v", bits, err)
+		}
+
+		phrase := string(m.Bytes())
+
+		got, err := FromMnemonic(phrase)
+		if err != nil {
+			t.Fatalf("FromMnemonic(%q) failed: %v", phrase, err)
+		}
+
+		if string(got.Bytes()) != phrase {
+			t.Errorf("round trip mismatch: got %q, want %q", got.Bytes(), phrase)
+		}
+	}
+}
+
+func TestFromMnemonicChecksumMismatch(t *testing.T) {
+	valid := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+	words := strings.Fields(valid)
+	words[len(words)-1] = "zoo"
+	invalid := strings.Join(words, " ")
+
+	_, err := FromMnemonic(invalid)
+	if err == nil {
+		t.Fatal("expected checksum mismatch error, got nil")
+	}
+
+	if !errors.Is(err, ErrChecksumMismatch) {
+		t.Errorf("expected ErrChecksumMismatch, got %v", err)
+	}
+}
+
+func TestMnemonicSeedTREZORVector(t *testing.T) {
+	m, err := FromMnemonic(bip39Vectors[0].mnemonic)
+	if err != nil {
+		t.Fatalf("FromMnemonic failed: %v", err)
+	}
+
+	m.SetPassphrase("TREZOR")
+
+	const want = "c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04"
+	if got := m.Password(); got != want {
+		t.Errorf("Password() = %q, want %q (official BIP39 TREZOR seed test vector)", got, want)
+	}
+}
+
+func TestNewMnemonicWordlistUnknown(t *testing.T) {
+	if _, err := NewMnemonicWordlist(128, "klingon"); err == nil {
+		t.Fatal("expected an error for an unknown wordlist")
+	}
+}
+
+func TestMnemonicPasswordDeterministic(t *testing.T) {
+	m, err := FromMnemonic(bip39Vectors[0].mnemonic)
+	if err != nil {
+		t.Fatalf("FromMnemonic failed: %v", err)
+	}
+
+	a := m.Password()
+	b := m.Password()
+
+	if a != b {
+		t.Errorf("Password() is not deterministic: %q != %q", a, b)
+	}
+
+	if _, err := hex.DecodeString(a); err != nil {
+		t.Errorf("Password() is not valid hex: %v", err)
+	}
+}
+
+func TestEntropyRoundTrip(t *testing.T) {
+	entropy, err := hex.DecodeString(bip39Vectors[0].entropy)
+	if err != nil {
+		t.Fatalf("bad test fixture: %v", err)
+	}
+
+	m, err := entropyToMnemonic(entropy, DefaultWordlist)
+	if err != nil {
+		t.Fatalf("entropyToMnemonic failed: %v", err)
+	}
+
+	if got := string(m.Bytes()); got != bip39Vectors[0].mnemonic {
+		t.Errorf("entropyToMnemonic() = %q, want %q", got, bip39Vectors[0].mnemonic)
+	}
+
+	decoded, err := FromMnemonic(string(m.Bytes()))
+	if err != nil {
+		t.Fatalf("FromMnemonic failed: %v", err)
+	}
+
+	roundTripEntropy := bitsToBytes(bytesToBits(entropy))
+	if !bytes.Equal(roundTripEntropy, entropy) {
+		t.Errorf("bit packing round trip mismatch")
+	}
+
+	if string(decoded.Bytes()) != string(m.Bytes()) {
+		t.Errorf("decoded phrase mismatch")
+	}
+}
@@ -0,0 +1,131 @@
+package secrets
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ParseMode selects how the body of a secret (everything after the first,
+// password line) is decoded.
+type ParseMode int
+
+const (
+	// ModeLenient is the historic gopass behavior: try to decode the body as
+	// YAML key/value pairs, and silently fall back to treating it as an
+	// opaque plain-text body if that fails.
+	ModeLenient ParseMode = iota
+	// ModeStrict fails loudly, with the line number yaml.v3 reports, on any
+	// body that is not valid YAML. No column is reported.
+	ModeStrict
+	// ModeKV never attempts YAML decoding; the body is always treated as an
+	// opaque tail, same as the legacy key/value-only secret format.
+	ModeKV
+)
+
+// ConfigKeyParsingMode is the config (and per-mount override) key a gopass
+// config layer would read to select a ParseMode; wiring it into gopass's
+// actual config package is not part of this change.
+const ConfigKeyParsingMode = "parsing.mode"
+
+// ParseModeFromString parses the config value for parsing.mode.
+func ParseModeFromString(s string) (ParseMode, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", "lenient":
+		return ModeLenient, nil
+	case "strict":
+		return ModeStrict, nil
+	case "kv-only", "kv":
+		return ModeKV, nil
+	default:
+		return ModeLenient, fmt.Errorf("secrets: unknown parsing mode %q", s)
+	}
+}
+
+func (m ParseMode) String() string {
+	switch m {
+	case ModeStrict:
+		return "strict"
+	case ModeKV:
+		return "kv-only"
+	default:
+		return "lenient"
+	}
+}
+
+// ErrStrictYAML is wrapped around the underlying yaml.v3 error (which
+// carries the line number, but not the column) when ModeStrict rejects a
+// body.
+type ErrStrictYAML struct {
+	err error
+}
+
+func (e *ErrStrictYAML) Error() string {
+	return fmt.Sprintf("secrets: strict YAML parsing failed: %s", e.err)
+}
+
+func (e *ErrStrictYAML) Unwrap() error {
+	return e.err
+}
+
+// Parse decodes a raw secret buffer (password line, optionally followed by
+// a YAML or plain-text body) according to mode.
+func Parse(in []byte, mode ParseMode) (*KV, error) {
+	raw := in
+	pwd, body, _ := splitPassword(in)
+
+	kv := &KV{
+		password: pwd,
+		raw:      raw,
+	}
+
+	if mode == ModeKV || len(body) == 0 {
+		kv.body = string(body)
+
+		return kv, nil
+	}
+
+	pairs, err := decodeYAMLBody(body)
+	if err != nil {
+		if mode == ModeStrict {
+			return nil, &ErrStrictYAML{err: err}
+		}
+
+		// ModeLenient: keep the body verbatim and remember that we fell
+		// back, so a later Bytes() does not reformat (and thereby mangle)
+		// a body that was never actually YAML.
+		kv.body = string(body)
+		kv.lenientFallback = true
+
+		return kv, nil
+	}
+
+	kv.pairs = pairs
+
+	return kv, nil
+}
+
+// decodeYAMLBody tries to decode body as a flat YAML mapping of string to
+// string, the only shape gopass secret bodies use.
+func decodeYAMLBody(body []byte) (map[string]string, error) {
+	var pairs map[string]string
+	if err := yaml.Unmarshal(body, &pairs); err != nil {
+		return nil, err
+	}
+
+	return pairs, nil
+}
+
+// splitPassword splits a secret buffer into its first line (the password)
+// and the remaining body.
+func splitPassword(in []byte) (pwd string, body []byte, hadBody bool) {
+	s := string(in)
+
+	idx := strings.IndexByte(s, '\n')
+	if idx < 0 {
+		return s, nil, false
+	}
+
+	return s[:idx], in[idx+1:], true
+}
@@ -0,0 +1,135 @@
+package secrets
+
+import (
+	"bytes"
+	"testing"
+)
+
+const invalidYAMLBody = `somepasswd
+---
+Test / test.com
+username: myuser@test.com
+password: someotherpasswd
+url: http://www.test.com/`
+
+func TestParseLenientFallback(t *testing.T) {
+	kv, err := Parse([]byte(invalidYAMLBody), ModeLenient)
+	if err != nil {
+		t.Fatalf("Parse(ModeLenient) returned an error: %v", err)
+	}
+
+	if !kv.lenientFallback {
+		t.Error("expected lenientFallback to be set for an unparsable body")
+	}
+
+	if !bytes.Equal(kv.Bytes(), []byte(invalidYAMLBody)) {
+		t.Errorf("Bytes() = %q, want verbatim original", kv.Bytes())
+	}
+}
+
+func TestParseStrictRejectsInvalidYAML(t *testing.T) {
+	_, err := Parse([]byte(invalidYAMLBody), ModeStrict)
+	if err == nil {
+		t.Fatal("expected Parse(ModeStrict) to fail on malformed YAML")
+	}
+
+	var strictErr *ErrStrictYAML
+	if !isStrictYAMLErr(err, &strictErr) {
+		t.Errorf("expected *ErrStrictYAML, got %T: %v", err, err)
+	}
+}
+
+func isStrictYAMLErr(err error, target **ErrStrictYAML) bool {
+	if e, ok := err.(*ErrStrictYAML); ok {
+		*target = e
+
+		return true
+	}
+
+	return false
+}
+
+func TestParseKVOnlyNeverDecodesYAML(t *testing.T) {
+	body := "password\nfoo: bar\n"
+
+	kv, err := Parse([]byte(body), ModeKV)
+	if err != nil {
+		t.Fatalf("Parse(ModeKV) returned an error: %v", err)
+	}
+
+	if len(kv.pairs) != 0 {
+		t.Errorf("expected no decoded pairs in ModeKV, got %v", kv.pairs)
+	}
+
+	if kv.Body() != "foo: bar\n" {
+		t.Errorf("Body() = %q, want opaque tail", kv.Body())
+	}
+}
+
+func TestParseValidYAMLBody(t *testing.T) {
+	body := "somepass\nuser: alice\nurl: example.com\n"
+
+	kv, err := Parse([]byte(body), ModeStrict)
+	if err != nil {
+		t.Fatalf("Parse(ModeStrict) failed on valid YAML: %v", err)
+	}
+
+	if v, ok := kv.Get("user"); !ok || v != "alice" {
+		t.Errorf("Get(%q) = %q, %v, want %q, true", "user", v, ok, "alice")
+	}
+}
+
+func TestKVBytesReencodesAfterModification(t *testing.T) {
+	kv, err := Parse([]byte(invalidYAMLBody), ModeLenient)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	kv.Set("new-key", "new-value")
+
+	out := kv.Bytes()
+
+	if bytes.Equal(out, []byte(invalidYAMLBody)) {
+		t.Error("expected Bytes() to re-encode once a key was modified")
+	}
+
+	if v, ok := kv.Get("new-key"); !ok || v != "new-value" {
+		t.Errorf("Get(%q) = %q, %v, want %q, true", "new-key", v, ok, "new-value")
+	}
+
+	for _, want := range []string{
+		"Test / test.com",
+		"username: myuser@test.com",
+		"password: someotherpasswd",
+		"url: http://www.test.com/",
+	} {
+		if !bytes.Contains(out, []byte(want)) {
+			t.Errorf("Bytes() = %q, want it to still contain original line %q", out, want)
+		}
+	}
+}
+
+func TestParseModeFromString(t *testing.T) {
+	tests := map[string]ParseMode{
+		"":        ModeLenient,
+		"lenient": ModeLenient,
+		"strict":  ModeStrict,
+		"kv-only": ModeKV,
+		"KV-Only": ModeKV,
+	}
+
+	for in, want := range tests {
+		got, err := ParseModeFromString(in)
+		if err != nil {
+			t.Fatalf("ParseModeFromString(%q) failed: %v", in, err)
+		}
+
+		if got != want {
+			t.Errorf("ParseModeFromString(%q) = %v, want %v", in, got, want)
+		}
+	}
+
+	if _, err := ParseModeFromString("bogus"); err == nil {
+		t.Error("expected an error for an unknown parsing mode")
+	}
+}
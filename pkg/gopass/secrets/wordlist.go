@@ -0,0 +1,63 @@
+package secrets
+
+import (
+	"bufio"
+	"bytes"
+	"embed"
+	"fmt"
+)
+
+//go:embed wordlists/*.txt
+var wordlistFS embed.FS
+
+// wordlists maps a --wordlist name to the embedded asset backing it. Only
+// "english" ships today; additional BIP39 languages can be supported by
+// dropping a 2048-line file into wordlists/ and registering it here.
+var wordlists = map[string]string{
+	"english": "wordlists/english.txt",
+}
+
+// DefaultWordlist is used when no --wordlist is given.
+const DefaultWordlist = "english"
+
+// loadWordlist reads and validates one of the embedded BIP39 wordlists.
+// Every wordlist must contain exactly 2048 unique, newline-separated words.
+func loadWordlist(name string) ([]string, error) {
+	path, ok := wordlists[name]
+	if !ok {
+		return nil, fmt.Errorf("mnemonic: unknown wordlist %q", name)
+	}
+
+	raw, err := wordlistFS.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("mnemonic: failed to read wordlist %q: %w", name, err)
+	}
+
+	words := make([]string, 0, 2048)
+	seen := make(map[string]struct{}, 2048)
+
+	sc := bufio.NewScanner(bytes.NewReader(raw))
+	for sc.Scan() {
+		w := sc.Text()
+		if w == "" {
+			continue
+		}
+
+		if _, dup := seen[w]; dup {
+			return nil, fmt.Errorf("mnemonic: wordlist %q contains duplicate word %q", name, w)
+		}
+
+		seen[w] = struct{}{}
+		words = append(words, w)
+	}
+
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("mnemonic: failed to parse wordlist %q: %w", name, err)
+	}
+
+	if len(words) != 2048 {
+		return nil, fmt.Errorf("mnemonic: wordlist %q must contain 2048 words, has %d", name, len(words))
+	}
+
+	return words, nil
+}
@@ -0,0 +1,173 @@
+package termio
+
+import (
+	"fmt"
+	"sync"
+	"syscall"
+	"time"
+
+	"golang.org/x/term"
+)
+
+// MultiProgress renders several ProgressBars stacked on top of each other.
+// It owns a single render goroutine so that concurrent bars (e.g. one per
+// worker during a bulk copy or re-encrypt) never interleave their output.
+//
+// When stderr is not a terminal, or GOPASS_PROGRESS=json is set, MultiProgress
+// degrades to sequential output: each bar prints its own line (or JSON event)
+// independently, same as a standalone ProgressBar, instead of repainting a
+// block of N lines in place.
+type MultiProgress struct {
+	mu   sync.Mutex
+	bars []*namedBar
+
+	once     sync.Once
+	stopOnce sync.Once
+	stopCh   chan struct{}
+	doneCh   chan struct{}
+	lastDraw int
+}
+
+type namedBar struct {
+	id  string
+	bar *ProgressBar
+}
+
+// NewMultiProgress creates a new, empty multi-bar container.
+func NewMultiProgress() *MultiProgress {
+	return &MultiProgress{
+		stopCh: make(chan struct{}),
+		doneCh: make(chan struct{}),
+	}
+}
+
+// Add registers a new bar with the given id (used as its description and,
+// in JSON mode, the "id" field of its events) and starts the render
+// goroutine on first use.
+func (m *MultiProgress) Add(id string, total int64) *ProgressBar {
+	bar := NewProgressBar(total)
+	bar.SetDescription(id)
+
+	// When the container drives its own render loop, it is the only thing
+	// allowed to write this bar's line to Stderr: a bar calling print() on
+	// itself from the caller's goroutine would race with renderLoop's
+	// redraw() and garble the output. In sequentialMode there is no render
+	// loop, so the bar must keep printing for itself.
+	bar.Hidden = !sequentialMode()
+
+	m.mu.Lock()
+	m.bars = append(m.bars, &namedBar{id: id, bar: bar})
+	m.mu.Unlock()
+
+	m.once.Do(m.start)
+
+	return bar
+}
+
+// Remove stops tracking a bar, e.g. once it is done and should no longer
+// occupy a line.
+func (m *MultiProgress) Remove(bar *ProgressBar) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for i, nb := range m.bars {
+		if nb.bar == bar {
+			m.bars = append(m.bars[:i], m.bars[i+1:]...)
+
+			return
+		}
+	}
+}
+
+// Stop ends the render goroutine and clears the drawn block, if any. Safe
+// to call more than once, including concurrently.
+func (m *MultiProgress) Stop() {
+	m.stopOnce.Do(func() {
+		close(m.stopCh)
+	})
+
+	<-m.doneCh
+}
+
+// sequentialMode reports whether MultiProgress degrades to per-bar,
+// standalone printing instead of driving its own render loop.
+func sequentialMode() bool {
+	return jsonProgressMode() || !term.IsTerminal(int(syscall.Stderr)) //nolint:unconvert
+}
+
+func (m *MultiProgress) start() {
+	if sequentialMode() {
+		// degrade to sequential, single-line-per-update output. Each bar
+		// already knows how to print (or emit JSON for) itself, so there is
+		// nothing for the render goroutine to do.
+		close(m.doneCh)
+
+		return
+	}
+
+	go m.renderLoop()
+}
+
+func (m *MultiProgress) renderLoop() {
+	defer close(m.doneCh)
+
+	ticker := time.NewTicker(time.Second / fps)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-m.stopCh:
+			m.redraw()
+			m.clear()
+
+			return
+		case <-ticker.C:
+			m.redraw()
+		}
+	}
+}
+
+// redraw repaints all tracked bars in place using ANSI cursor movement:
+// move the cursor back up over the previously drawn block, save it, print
+// every bar's line, then restore and move the cursor down past the block,
+// so the next tick (or clear) moves up over and overwrites the same lines.
+func (m *MultiProgress) redraw() {
+	m.mu.Lock()
+	bars := make([]*namedBar, len(m.bars))
+	copy(bars, m.bars)
+	m.mu.Unlock()
+
+	if len(bars) == 0 {
+		return
+	}
+
+	if m.lastDraw > 0 {
+		fmt.Fprintf(Stderr, "\033[%dA", m.lastDraw)
+	}
+
+	fmt.Fprint(Stderr, "\033[s")
+
+	for _, nb := range bars {
+		clearLine()
+		nb.bar.renderLine()
+		fmt.Fprintln(Stderr)
+	}
+
+	fmt.Fprintf(Stderr, "\033[u\033[%dB", len(bars))
+	m.lastDraw = len(bars)
+}
+
+func (m *MultiProgress) clear() {
+	if m.lastDraw == 0 {
+		return
+	}
+
+	fmt.Fprintf(Stderr, "\033[%dA", m.lastDraw)
+
+	for i := 0; i < m.lastDraw; i++ {
+		clearLine()
+		fmt.Fprintln(Stderr)
+	}
+
+	fmt.Fprintf(Stderr, "\033[%dA", m.lastDraw)
+}
@@ -1,9 +1,12 @@
 package termio
 
 import (
+	"encoding/json"
 	"fmt"
 	"math"
+	"os"
 	"strings"
+	"sync"
 	"sync/atomic"
 	"syscall"
 	"time"
@@ -15,6 +18,10 @@ import (
 
 const (
 	fps = 25
+
+	// ewmaAlpha is the smoothing factor used to compute the rate. A higher
+	// value reacts faster to recent ticks, at the cost of more jitter.
+	ewmaAlpha = 0.3
 )
 
 var now = time.Now
@@ -27,8 +34,22 @@ type ProgressBar struct {
 	total   int64
 	current int64
 
-	mutex   chan struct{}
+	mutex chan struct{}
+
+	// stateMu guards lastUpd, start, lastVal and rate: tryPrint/updateRate
+	// write them from whichever goroutine calls Add/Inc/Set, renderLine
+	// (via updateRate) writes them from a MultiProgress's dedicated render
+	// goroutine, and eta and printJSON may read them concurrently.
+	stateMu sync.RWMutex
 	lastUpd time.Time
+	start   time.Time
+	lastVal int64
+	rate    float64
+
+	throttle time.Duration
+
+	descMu sync.RWMutex
+	desc   string
 
 	Hidden bool
 	Bytes  bool
@@ -40,7 +61,37 @@ func NewProgressBar(total int64) *ProgressBar {
 		total:   total,
 		current: 0,
 		mutex:   make(chan struct{}, 1),
+		start:   now(),
+	}
+}
+
+// SetDescription sets a short label shown alongside the bar (and used as the
+// "id" of emitted JSON events). Safe for concurrent use.
+func (p *ProgressBar) SetDescription(d string) {
+	if p == nil {
+		return
+	}
+
+	p.descMu.Lock()
+	p.desc = d
+	p.descMu.Unlock()
+}
+
+func (p *ProgressBar) description() string {
+	p.descMu.RLock()
+	defer p.descMu.RUnlock()
+
+	return p.desc
+}
+
+// Throttle limits how often the bar is redrawn, overriding the default
+// fps-based interval. A zero duration restores the default.
+func (p *ProgressBar) Throttle(d time.Duration) {
+	if p == nil {
+		return
 	}
+
+	p.throttle = d
 }
 
 // Add adds the given amount to the progress.
@@ -132,15 +183,112 @@ func (p *ProgressBar) print() {
 
 func (p *ProgressBar) tryPrint() {
 	ts := now()
-	if p.current == 0 || p.current >= p.total-1 || ts.Sub(p.lastUpd) > time.Second/fps {
-		p.lastUpd = ts
+	interval := time.Second / fps
+	if p.throttle > 0 {
+		interval = p.throttle
+	}
+
+	p.stateMu.RLock()
+	lastUpd := p.lastUpd
+	p.stateMu.RUnlock()
+
+	if p.current == 0 || p.current >= p.total-1 || ts.Sub(lastUpd) > interval {
+		p.updateRate(ts)
 		p.doPrint()
 	}
 }
 
+// updateRate refreshes the exponentially-weighted moving average of the
+// progress rate (items or bytes per second).
+func (p *ProgressBar) updateRate(ts time.Time) {
+	cur := atomic.LoadInt64(&p.current)
+
+	p.stateMu.Lock()
+	defer p.stateMu.Unlock()
+
+	ref := p.lastUpd
+	if ref.IsZero() {
+		ref = p.start
+	}
+
+	elapsed := ts.Sub(ref).Seconds()
+
+	if elapsed <= 0 {
+		p.lastVal = cur
+		p.lastUpd = ts
+
+		return
+	}
+
+	inst := float64(cur-p.lastVal) / elapsed
+	if p.rate == 0 {
+		p.rate = inst
+	} else {
+		p.rate = ewmaAlpha*inst + (1-ewmaAlpha)*p.rate
+	}
+
+	p.lastVal = cur
+	p.lastUpd = ts
+}
+
+// eta estimates the remaining duration based on the current rate.
+func (p *ProgressBar) eta(cur, maxVal int64) time.Duration {
+	p.stateMu.RLock()
+	rate := p.rate
+	p.stateMu.RUnlock()
+
+	if rate <= 0 || maxVal <= cur {
+		return 0
+	}
+
+	return time.Duration(float64(maxVal-cur) / rate * float64(time.Second))
+}
+
+// jsonProgressEvent is a single newline-delimited JSON progress update,
+// emitted instead of the ANSI bar when stderr is not a terminal or when
+// GOPASS_PROGRESS=json is set.
+type jsonProgressEvent struct {
+	ID      string  `json:"id,omitempty"`
+	Current int64   `json:"current"`
+	Total   int64   `json:"total"`
+	Rate    float64 `json:"rate"`
+	ETAMs   int64   `json:"eta_ms"`
+}
+
+// jsonProgressMode reports whether progress should be emitted as
+// newline-delimited JSON events rather than an ANSI redrawn bar.
+func jsonProgressMode() bool {
+	return os.Getenv("GOPASS_PROGRESS") == "json" || !term.IsTerminal(int(syscall.Stderr)) //nolint:unconvert
+}
+
+func (p *ProgressBar) printJSON() {
+	cur, maxVal, _ := p.percent()
+
+	p.stateMu.RLock()
+	rate := p.rate
+	p.stateMu.RUnlock()
+
+	ev := jsonProgressEvent{
+		ID:      p.description(),
+		Current: cur,
+		Total:   maxVal,
+		Rate:    rate,
+		ETAMs:   p.eta(cur, maxVal).Milliseconds(),
+	}
+
+	enc := json.NewEncoder(Stderr)
+	_ = enc.Encode(ev)
+}
+
 // doPrint redraws the current line.
 // This method is based on https://github.com/muesli/goprogressbar/blob/master/progressbar.go#L96
 func (p *ProgressBar) doPrint() {
+	if jsonProgressMode() {
+		p.printJSON()
+
+		return
+	}
+
 	clearLine()
 
 	cur, maxVal, pct := p.percent()
@@ -214,6 +362,48 @@ func boundedMin(a, b int) int {
 	return gteZero(min(a, b))
 }
 
+// renderLine writes a single, self-contained status line for this bar
+// (description, percentage, throughput and ETA) without clearing the line
+// or emitting a trailing newline, so a MultiProgress can stack several of
+// them. It is not used by the standalone single-bar doPrint, which keeps
+// its existing layout for backwards compatibility. It refreshes the rate
+// itself, since a bar drawn by a MultiProgress is Hidden and never reaches
+// tryPrint.
+func (p *ProgressBar) renderLine() {
+	p.updateRate(now())
+
+	cur, maxVal, pct := p.percent()
+
+	p.stateMu.RLock()
+	curRate := p.rate
+	start := p.start
+	p.stateMu.RUnlock()
+
+	rate := "-"
+	if curRate > 0 {
+		if p.Bytes {
+			rate = humanize.Bytes(uint64(curRate)) + "/s"
+		} else {
+			rate = fmt.Sprintf("%.1f/s", curRate)
+		}
+	}
+
+	eta := "-"
+	if d := p.eta(cur, maxVal); d > 0 {
+		eta = d.Truncate(time.Second).String()
+	}
+
+	elapsed := now().Sub(start).Truncate(time.Second)
+
+	desc := p.description()
+	if desc == "" {
+		desc = "progress"
+	}
+
+	fmt.Fprintf(Stderr, "%-16s %6.2f%%  rate: %-10s  eta: %-8s  elapsed: %s",
+		desc, pct*100, rate, eta, elapsed)
+}
+
 func (p *ProgressBar) percent() (int64, int64, float64) {
 	cur := atomic.LoadInt64(&p.current)
 	maxVal := atomic.LoadInt64(&p.total)